@@ -0,0 +1,174 @@
+package convert
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+var byteSliceType = reflect.TypeOf([]byte(nil))
+
+// implementsAny reports whether field's type, or a pointer to it when
+// field is addressable, implements any of ifaces. It mirrors the
+// pointer/interface collapsing marshall and unmarshall do, so it agrees
+// with them about which types they'll actually handle.
+func implementsAny(field reflect.Value, ifaces ...reflect.Type) bool {
+	dup := field
+	for dup.Kind() == reflect.Interface || dup.Kind() == reflect.Ptr {
+		if dup.IsNil() {
+			break
+		}
+		dup = dup.Elem()
+	}
+
+	t := dup.Type()
+	if dup.CanAddr() {
+		t = dup.Addr().Type()
+	}
+	for _, iface := range ifaces {
+		if t.Implements(iface) {
+			return true
+		}
+	}
+	return false
+}
+
+// setCollectionField handles []byte, other slices, arrays, and maps, which
+// round-trip through a separator-joined string rather than gocsv's
+// generic kind-based conversion. Types implementing TypeUnmarshaller or
+// sql.Scanner take priority, matching the pre-existing default-branch
+// fallback in SetField, so a slice/map type with custom unmarshalling isn't
+// silently shadowed. The bool result reports whether field was handled by
+// this function; when false, the caller should fall through to the rest of
+// its conversion pipeline.
+func (r *Registry) setCollectionField(field reflect.Value, value string, row int, column string, opts FieldOptions) (bool, error) {
+	t := field.Type()
+
+	if implementsAny(field, unMarshallerType, scannerType) {
+		return false, nil
+	}
+
+	if t == byteSliceType {
+		if value == "" {
+			field.SetBytes(nil)
+			return true, nil
+		}
+		decode := base64.StdEncoding.DecodeString
+		if opts.Hex {
+			decode = hex.DecodeString
+		}
+		decoded, err := decode(value)
+		if err != nil {
+			return true, err
+		}
+		field.SetBytes(decoded)
+		return true, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Slice:
+		if value == "" {
+			field.Set(reflect.MakeSlice(t, 0, 0))
+			return true, nil
+		}
+		parts := strings.Split(value, opts.sep())
+		out := reflect.MakeSlice(t, len(parts), len(parts))
+		for i, part := range parts {
+			if err := r.SetField(out.Index(i), part, row, column, opts); err != nil {
+				return true, err
+			}
+		}
+		field.Set(out)
+		return true, nil
+	case reflect.Array:
+		if value == "" {
+			return true, nil
+		}
+		parts := strings.Split(value, opts.sep())
+		if len(parts) != t.Len() {
+			return true, fmt.Errorf("gocsv: cannot unmarshal %d elements into %s", len(parts), t.String())
+		}
+		for i, part := range parts {
+			if err := r.SetField(field.Index(i), part, row, column, opts); err != nil {
+				return true, err
+			}
+		}
+		return true, nil
+	case reflect.Map:
+		out := reflect.MakeMap(t)
+		if value != "" {
+			for _, entry := range strings.Split(value, opts.sep()) {
+				k, v, ok := strings.Cut(entry, opts.kv())
+				if !ok {
+					return true, fmt.Errorf("gocsv: malformed map entry %q for %s", entry, t.String())
+				}
+				keyVal := reflect.New(t.Key()).Elem()
+				if err := r.SetField(keyVal, k, row, column, opts); err != nil {
+					return true, err
+				}
+				elemVal := reflect.New(t.Elem()).Elem()
+				if err := r.SetField(elemVal, v, row, column, opts); err != nil {
+					return true, err
+				}
+				out.SetMapIndex(keyVal, elemVal)
+			}
+		}
+		field.Set(out)
+		return true, nil
+	}
+	return false, nil
+}
+
+// getCollectionAsString is the encode-side counterpart of setCollectionField.
+// Types implementing TypeMarshaller, driver.Valuer, or Stringer take
+// priority, matching the pre-existing default-branch fallback in
+// GetFieldAsString, so a slice/map type with custom marshalling isn't
+// silently shadowed.
+func (r *Registry) getCollectionAsString(field reflect.Value, opts FieldOptions) (string, bool, error) {
+	t := field.Type()
+
+	if implementsAny(field, marshallerType, valuerType, stringerType) {
+		return "", false, nil
+	}
+
+	if t == byteSliceType {
+		if opts.Hex {
+			return hex.EncodeToString(field.Bytes()), true, nil
+		}
+		return base64.StdEncoding.EncodeToString(field.Bytes()), true, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		n := field.Len()
+		parts := make([]string, n)
+		for i := 0; i < n; i++ {
+			s, err := r.GetFieldAsString(field.Index(i), opts)
+			if err != nil {
+				return "", true, err
+			}
+			parts[i] = s
+		}
+		return strings.Join(parts, opts.sep()), true, nil
+	case reflect.Map:
+		parts := make([]string, 0, field.Len())
+		iter := field.MapRange()
+		for iter.Next() {
+			k, err := r.GetFieldAsString(iter.Key(), opts)
+			if err != nil {
+				return "", true, err
+			}
+			v, err := r.GetFieldAsString(iter.Value(), opts)
+			if err != nil {
+				return "", true, err
+			}
+			parts = append(parts, k+opts.kv()+v)
+		}
+		sort.Strings(parts)
+		return strings.Join(parts, opts.sep()), true, nil
+	}
+	return "", false, nil
+}
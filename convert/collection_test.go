@@ -0,0 +1,68 @@
+package convert
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestCollectionRoundTrip(t *testing.T) {
+	type row struct {
+		Tags []string
+	}
+	var r row
+	v := reflect.ValueOf(&r).Elem()
+	opts := FieldOptions{Sep: "|"}
+
+	if err := DefaultRegistry.SetField(v.FieldByName("Tags"), "a|b|c", 0, "Tags", opts); err != nil {
+		t.Fatalf("SetField: %v", err)
+	}
+	if fmt.Sprint(r.Tags) != "[a b c]" {
+		t.Fatalf("Tags = %v, want [a b c]", r.Tags)
+	}
+
+	s, err := DefaultRegistry.GetFieldAsString(v.FieldByName("Tags"), opts)
+	if err != nil {
+		t.Fatalf("GetFieldAsString: %v", err)
+	}
+	if s != "a|b|c" {
+		t.Fatalf("GetFieldAsString = %q, want a|b|c", s)
+	}
+}
+
+type customIntList []int
+
+func (c customIntList) MarshalCSV() (string, error) {
+	return "CUSTOM", nil
+}
+
+func (c *customIntList) UnmarshalCSV(s string) error {
+	*c = customIntList{999}
+	return nil
+}
+
+// TestCollectionPrefersCustomMarshaller guards against the generic
+// slice/map handling shadowing a type's own TypeMarshaller/TypeUnmarshaller,
+// which must still take priority per the documented conversion order.
+func TestCollectionPrefersCustomMarshaller(t *testing.T) {
+	type row struct {
+		List customIntList
+	}
+	r := row{List: customIntList{1, 2, 3}}
+	v := reflect.ValueOf(&r).Elem()
+
+	s, err := DefaultRegistry.GetFieldAsString(v.FieldByName("List"), FieldOptions{})
+	if err != nil {
+		t.Fatalf("GetFieldAsString: %v", err)
+	}
+	if s != "CUSTOM" {
+		t.Fatalf("GetFieldAsString = %q, want CUSTOM", s)
+	}
+
+	if err := DefaultRegistry.SetField(v.FieldByName("List"), "1,2,3", 0, "List", FieldOptions{}); err != nil {
+		t.Fatalf("SetField: %v", err)
+	}
+	if fmt.Sprint(r.List) != "[999]" {
+		t.Fatalf("List = %v, want [999]", r.List)
+	}
+}
@@ -0,0 +1,431 @@
+// Package convert implements the value conversion logic used by gocsv to
+// move data between CSV cells and struct fields. It is organized around a
+// Registry so that callers can register first-class support for types they
+// don't own (net.IP, uuid.UUID, decimal.Decimal, ...) or override built-in
+// behavior for a single call site instead of relying on package-level state.
+package convert
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// --------------------------------------------------------------------------
+// Conversion interfaces
+
+// TypeMarshaller is implemented by any value that has a MarshalCSV method
+// This converter is used to convert the value to it string representation
+type TypeMarshaller interface {
+	MarshalCSV() (string, error)
+}
+
+// Stringer is implemented by any value that has a String method
+// This converter is used to convert the value to it string representation
+// This converter will be used if your value does not implement TypeMarshaller
+type Stringer interface {
+	String() string
+}
+
+// TypeUnmarshaller is implemented by any value that has an UnmarshalCSV method
+// This converter is used to convert a string to your value representation of that string
+type TypeUnmarshaller interface {
+	UnmarshalCSV(string) error
+}
+
+var (
+	stringerType     = reflect.TypeOf((*Stringer)(nil)).Elem()
+	marshallerType   = reflect.TypeOf((*TypeMarshaller)(nil)).Elem()
+	unMarshallerType = reflect.TypeOf((*TypeUnmarshaller)(nil)).Elem()
+	scannerType      = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	valuerType       = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+)
+
+// OverflowError is returned by (*Registry).SetField when a numeric CSV value
+// cannot be represented by the destination field's concrete type without
+// truncation, or when a negative value targets an unsigned field. Row and
+// Column carry the source position so callers running in error-collection
+// mode can aggregate and report every bad cell instead of failing on the
+// first one.
+type OverflowError struct {
+	Value  string
+	Type   reflect.Type
+	Row    int
+	Column string
+}
+
+func (e *OverflowError) Error() string {
+	return fmt.Sprintf("value %q overflows %s at row %d, column %q", e.Value, e.Type, e.Row, e.Column)
+}
+
+// EncodeFunc converts a field's value to its string representation.
+type EncodeFunc func(reflect.Value) (string, error)
+
+// DecodeFunc parses a string into field, assigning the result in place.
+type DecodeFunc func(reflect.Value, string) error
+
+type typeConverter struct {
+	enc EncodeFunc
+	dec DecodeFunc
+}
+
+// Registry holds per-type converters that take priority over gocsv's built-in
+// kind-based conversion. A zero-value Registry is ready to use and behaves
+// exactly like the package-level default registry. Registries are safe to
+// share across goroutines once registration is complete, but
+// RegisterTypeConverter itself is not safe to call concurrently with use.
+type Registry struct {
+	converters map[reflect.Type]typeConverter
+}
+
+// NewRegistry returns an empty Registry with no custom type converters
+// registered, falling back entirely to gocsv's built-in conversions.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// DefaultRegistry is the Registry used when no other Registry is supplied.
+// This tree has no reader/writer/Marshal*/Unmarshal* entry points yet for a
+// WithRegistry option to configure; callers that want an isolated registry
+// today can call (*Registry).SetField/GetFieldAsString directly on one
+// built with NewRegistry.
+var DefaultRegistry = NewRegistry()
+
+// RegisterTypeConverter registers enc and dec as the conversion functions for
+// t, taking priority over TypeMarshaller/TypeUnmarshaller, sql.Scanner/
+// driver.Valuer, Stringer, and gocsv's built-in kind-based conversion. Passing
+// a nil enc or dec leaves that direction unregistered.
+func (r *Registry) RegisterTypeConverter(t reflect.Type, enc EncodeFunc, dec DecodeFunc) {
+	if r.converters == nil {
+		r.converters = make(map[reflect.Type]typeConverter)
+	}
+	r.converters[t] = typeConverter{enc: enc, dec: dec}
+}
+
+// RegisterTypeConverter registers enc and dec for t on the default registry.
+func RegisterTypeConverter(t reflect.Type, enc EncodeFunc, dec DecodeFunc) {
+	DefaultRegistry.RegisterTypeConverter(t, enc, dec)
+}
+
+func (r *Registry) converterFor(t reflect.Type) (typeConverter, bool) {
+	if r.converters == nil {
+		return typeConverter{}, false
+	}
+	tc, ok := r.converters[t]
+	return tc, ok
+}
+
+// --------------------------------------------------------------------------
+// Conversion helpers
+
+func toString(in interface{}) (string, error) {
+	inValue := reflect.ValueOf(in)
+
+	switch inValue.Kind() {
+	case reflect.String:
+		return inValue.String(), nil
+	case reflect.Bool:
+		b := inValue.Bool()
+		if b {
+			return "true", nil
+		}
+		return "false", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(inValue.Int(), 10), nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(inValue.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		bitSize := 64
+		if inValue.Kind() == reflect.Float32 {
+			bitSize = 32
+		}
+		return strconv.FormatFloat(inValue.Float(), byte('f'), -1, bitSize), nil
+	}
+	return "", fmt.Errorf("No known conversion from " + inValue.Type().String() + " to string")
+}
+
+func toBool(in interface{}) (bool, error) {
+	inValue := reflect.ValueOf(in)
+
+	switch inValue.Kind() {
+	case reflect.String:
+		s := inValue.String()
+		if s == "true" || s == "yes" || s == "1" {
+			return true, nil
+		} else if s == "false" || s == "no" || s == "0" {
+			return false, nil
+		}
+	case reflect.Bool:
+		return inValue.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := inValue.Int()
+		if i != 0 {
+			return true, nil
+		}
+		return false, nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i := inValue.Uint()
+		if i != 0 {
+			return true, nil
+		}
+		return false, nil
+	case reflect.Float32, reflect.Float64:
+		f := inValue.Float()
+		if f != 0 {
+			return true, nil
+		}
+		return false, nil
+	}
+	return false, fmt.Errorf("No known conversion from " + inValue.Type().String() + " to bool")
+}
+
+func toInt(in interface{}) (int64, error) {
+	inValue := reflect.ValueOf(in)
+
+	switch inValue.Kind() {
+	case reflect.String:
+		return strconv.ParseInt(inValue.String(), 0, 64)
+	case reflect.Bool:
+		if inValue.Bool() {
+			return 1, nil
+		}
+		return 0, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return inValue.Int(), nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(inValue.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return int64(inValue.Float()), nil
+	}
+	return 0, fmt.Errorf("No known conversion from " + inValue.Type().String() + " to int")
+}
+
+func toUint(in interface{}) (uint64, error) {
+	inValue := reflect.ValueOf(in)
+
+	switch inValue.Kind() {
+	case reflect.String:
+		return strconv.ParseUint(inValue.String(), 0, 64)
+	case reflect.Bool:
+		if inValue.Bool() {
+			return 1, nil
+		}
+		return 0, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return uint64(inValue.Int()), nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return inValue.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		return uint64(inValue.Float()), nil
+	}
+	return 0, fmt.Errorf("No known conversion from " + inValue.Type().String() + " to uint")
+}
+
+func toFloat(in interface{}) (float64, error) {
+	inValue := reflect.ValueOf(in)
+
+	switch inValue.Kind() {
+	case reflect.String:
+		return strconv.ParseFloat(inValue.String(), 64)
+	case reflect.Bool:
+		if inValue.Bool() {
+			return 1, nil
+		}
+		return 0, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(inValue.Int()), nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(inValue.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return inValue.Float(), nil
+	}
+	return 0, fmt.Errorf("No known conversion from " + inValue.Type().String() + " to float")
+}
+
+// SetField assigns value, a raw CSV cell, to field using r's registered
+// converters, falling back to gocsv's built-in kind-based conversion. row and
+// column identify the cell's position in the source CSV and are only used to
+// annotate *OverflowError when the parsed value doesn't fit field's concrete
+// type. opts carries the extended tag directives (format=, tz=, ...) parsed
+// from the field's csv tag.
+func (r *Registry) SetField(field reflect.Value, value string, row int, column string, opts FieldOptions) error {
+	if tc, ok := r.converterFor(field.Type()); ok && tc.dec != nil {
+		return tc.dec(field, value)
+	}
+	if handled, err := setTimeField(field, value, opts); handled {
+		return err
+	}
+	if handled, err := r.setCollectionField(field, value, row, column, opts); handled {
+		return err
+	}
+	if handled, err := r.setNumericField(field, value, row, column, opts); handled {
+		return err
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		s, err := toString(value)
+		if err != nil {
+			return err
+		}
+		field.SetString(s)
+	case reflect.Bool:
+		b, err := toBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := toInt(value)
+		if errors.Is(err, strconv.ErrRange) {
+			return &OverflowError{Value: value, Type: field.Type(), Row: row, Column: column}
+		}
+		if err != nil {
+			return err
+		}
+		if field.OverflowInt(i) {
+			return &OverflowError{Value: value, Type: field.Type(), Row: row, Column: column}
+		}
+		field.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if strings.HasPrefix(strings.TrimSpace(value), "-") {
+			return &OverflowError{Value: value, Type: field.Type(), Row: row, Column: column}
+		}
+		ui, err := toUint(value)
+		if errors.Is(err, strconv.ErrRange) {
+			return &OverflowError{Value: value, Type: field.Type(), Row: row, Column: column}
+		}
+		if err != nil {
+			return err
+		}
+		if field.OverflowUint(ui) {
+			return &OverflowError{Value: value, Type: field.Type(), Row: row, Column: column}
+		}
+		field.SetUint(ui)
+	case reflect.Float32, reflect.Float64:
+		f, err := toFloat(value)
+		if errors.Is(err, strconv.ErrRange) {
+			return &OverflowError{Value: value, Type: field.Type(), Row: row, Column: column}
+		}
+		if err != nil {
+			return err
+		}
+		if field.OverflowFloat(f) {
+			return &OverflowError{Value: value, Type: field.Type(), Row: row, Column: column}
+		}
+		field.SetFloat(f)
+	default:
+		return r.unmarshall(field, value)
+	}
+	return nil
+}
+
+// GetFieldAsString renders field's value as a CSV cell using r's registered
+// converters, falling back to gocsv's built-in kind-based conversion. opts
+// carries the extended tag directives (format=, tz=, ...) parsed from the
+// field's csv tag.
+func (r *Registry) GetFieldAsString(field reflect.Value, opts FieldOptions) (str string, err error) {
+	if tc, ok := r.converterFor(field.Type()); ok && tc.enc != nil {
+		return tc.enc(field)
+	}
+	if s, handled, err := getTimeField(field, opts); handled {
+		return s, err
+	}
+	if s, handled, err := r.getCollectionAsString(field, opts); handled {
+		return s, err
+	}
+	if s, handled, err := r.getNumericAsString(field, opts); handled {
+		return s, err
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		return field.String(), nil
+	case reflect.Bool:
+		str, err = toString(field.Bool())
+		if err != nil {
+			return str, err
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		str, err = toString(field.Int())
+		if err != nil {
+			return str, err
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		str, err = toString(field.Uint())
+		if err != nil {
+			return str, err
+		}
+	case reflect.Float32, reflect.Float64:
+		str, err = toString(field.Float())
+		if err != nil {
+			return str, err
+		}
+	default:
+		return r.marshall(field)
+	}
+	return str, nil
+}
+
+// --------------------------------------------------------------------------
+// Un/serializations helpers
+
+func (r *Registry) unmarshall(field reflect.Value, value string) error {
+	dupField := field
+	unMarshallIt := func(finalField reflect.Value) error {
+		if finalField.CanInterface() && finalField.Type().Implements(unMarshallerType) {
+			if err := finalField.Interface().(TypeUnmarshaller).UnmarshalCSV(value); err != nil {
+				return err
+			}
+			return nil
+		}
+		if finalField.CanInterface() && finalField.Type().Implements(scannerType) {
+			return finalField.Interface().(sql.Scanner).Scan(value)
+		}
+		return fmt.Errorf("No known conversion from string to " + field.Type().String() + ", " + field.Type().String() + " does not implements TypeUnmarshaller")
+	}
+	for dupField.Kind() == reflect.Interface || dupField.Kind() == reflect.Ptr {
+		if dupField.IsNil() {
+			dupField = reflect.New(field.Type().Elem())
+			field.Set(dupField)
+			return unMarshallIt(dupField)
+		}
+		dupField = dupField.Elem()
+	}
+	if dupField.CanAddr() {
+		return unMarshallIt(dupField.Addr())
+	}
+	return fmt.Errorf("No known conversion from string to " + field.Type().String() + ", " + field.Type().String() + " does not implements TypeUnmarshaller")
+}
+
+func (r *Registry) marshall(field reflect.Value) (value string, err error) {
+	dupField := field
+	marshallIt := func(finalField reflect.Value) (string, error) {
+		if finalField.CanInterface() && finalField.Type().Implements(marshallerType) { // Use TypeMarshaller when possible
+			return finalField.Interface().(TypeMarshaller).MarshalCSV()
+		} else if finalField.CanInterface() && finalField.Type().Implements(valuerType) { // Otherwise try driver.Valuer
+			v, err := finalField.Interface().(driver.Valuer).Value()
+			if err != nil {
+				return value, err
+			}
+			return formatDriverValue(v)
+		} else if finalField.CanInterface() && finalField.Type().Implements(stringerType) { // Otherwise try to use Stringer
+			return finalField.Interface().(Stringer).String(), nil
+		}
+		return value, fmt.Errorf("No known conversion from " + field.Type().String() + " to string, " + field.Type().String() + " does not implements TypeMarshaller nor Stringer")
+	}
+	for dupField.Kind() == reflect.Interface || dupField.Kind() == reflect.Ptr {
+		if dupField.IsNil() {
+			return value, nil
+		}
+		dupField = dupField.Elem()
+	}
+	if dupField.CanAddr() {
+		return marshallIt(dupField.Addr())
+	}
+	return value, fmt.Errorf("No known conversion from " + field.Type().String() + " to string, " + field.Type().String() + " does not implements TypeMarshaller nor Stringer")
+}
@@ -0,0 +1,55 @@
+package convert
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestGetFieldAsStringPlainInts(t *testing.T) {
+	type row struct {
+		X int
+		U uint8
+	}
+	r := row{X: 12345, U: 250}
+	v := reflect.ValueOf(&r).Elem()
+
+	got, err := DefaultRegistry.GetFieldAsString(v.FieldByName("X"), FieldOptions{})
+	if err != nil {
+		t.Fatalf("GetFieldAsString(X): %v", err)
+	}
+	if got != "12345" {
+		t.Fatalf("GetFieldAsString(X) = %q, want %q", got, "12345")
+	}
+
+	got, err = DefaultRegistry.GetFieldAsString(v.FieldByName("U"), FieldOptions{})
+	if err != nil {
+		t.Fatalf("GetFieldAsString(U): %v", err)
+	}
+	if got != "250" {
+		t.Fatalf("GetFieldAsString(U) = %q, want %q", got, "250")
+	}
+}
+
+func TestSetFieldOverflow(t *testing.T) {
+	type row struct {
+		X int8
+		U uint8
+	}
+	var r row
+	v := reflect.ValueOf(&r).Elem()
+
+	var overflow *OverflowError
+	err := DefaultRegistry.SetField(v.FieldByName("X"), "70000", 3, "X", FieldOptions{})
+	if !errors.As(err, &overflow) {
+		t.Fatalf("SetField(X, 70000) error = %v, want *OverflowError", err)
+	}
+	if overflow.Row != 3 || overflow.Column != "X" {
+		t.Fatalf("OverflowError = %+v, want Row=3 Column=X", overflow)
+	}
+
+	err = DefaultRegistry.SetField(v.FieldByName("U"), "-1", 0, "U", FieldOptions{})
+	if !errors.As(err, &overflow) {
+		t.Fatalf("SetField(U, -1) error = %v, want *OverflowError", err)
+	}
+}
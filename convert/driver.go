@@ -0,0 +1,26 @@
+package convert
+
+import (
+	"database/sql/driver"
+	"encoding/base64"
+	"time"
+)
+
+// formatDriverValue renders a driver.Value (as returned by driver.Valuer)
+// to a CSV cell. driver.Value is documented to be one of a closed set of
+// types: int64, float64, bool, []byte, string, time.Time, or nil. Plain
+// scalars fall back to toString, but time.Time and []byte need the same
+// formatting getTimeField/getCollectionAsString use rather than toString's
+// kind-based conversion, which doesn't handle them at all.
+func formatDriverValue(v driver.Value) (string, error) {
+	switch tv := v.(type) {
+	case nil:
+		return "", nil
+	case time.Time:
+		return formatTime(tv, FieldOptions{})
+	case []byte:
+		return base64.StdEncoding.EncodeToString(tv), nil
+	default:
+		return toString(v)
+	}
+}
@@ -0,0 +1,159 @@
+package convert
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// setNumericField applies the base= and true=/false= tag directives ahead of
+// gocsv's default kind-based conversion. Pad and float directives only
+// affect marshalling, so they're handled in getNumericAsString instead. The
+// bool result reports whether field was handled; when false, the caller
+// should fall through to the rest of its conversion pipeline.
+func (r *Registry) setNumericField(field reflect.Value, value string, row int, column string, opts FieldOptions) (bool, error) {
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if opts.Base == 0 {
+			return false, nil
+		}
+		i, err := strconv.ParseInt(strings.TrimSpace(value), opts.Base, 64)
+		if errors.Is(err, strconv.ErrRange) {
+			return true, &OverflowError{Value: value, Type: field.Type(), Row: row, Column: column}
+		}
+		if err != nil {
+			return true, err
+		}
+		if field.OverflowInt(i) {
+			return true, &OverflowError{Value: value, Type: field.Type(), Row: row, Column: column}
+		}
+		field.SetInt(i)
+		return true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if opts.Base == 0 {
+			return false, nil
+		}
+		trimmed := strings.TrimSpace(value)
+		if strings.HasPrefix(trimmed, "-") {
+			return true, &OverflowError{Value: value, Type: field.Type(), Row: row, Column: column}
+		}
+		u, err := strconv.ParseUint(trimmed, opts.Base, 64)
+		if errors.Is(err, strconv.ErrRange) {
+			return true, &OverflowError{Value: value, Type: field.Type(), Row: row, Column: column}
+		}
+		if err != nil {
+			return true, err
+		}
+		if field.OverflowUint(u) {
+			return true, &OverflowError{Value: value, Type: field.Type(), Row: row, Column: column}
+		}
+		field.SetUint(u)
+		return true, nil
+	case reflect.Bool:
+		if opts.True != "" && value == opts.True {
+			field.SetBool(true)
+			return true, nil
+		}
+		if opts.False != "" && value == opts.False {
+			field.SetBool(false)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// getNumericAsString applies the base=, prec=/fmt=, pad=, and true=/false=
+// tag directives ahead of gocsv's default kind-based conversion. The bool
+// result reports whether field was handled; when false, the caller should
+// fall through to the rest of its conversion pipeline.
+func (r *Registry) getNumericAsString(field reflect.Value, opts FieldOptions) (string, bool, error) {
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if opts.Base == 0 && opts.Pad == 0 {
+			return "", false, nil
+		}
+		base, err := normalizeBase(opts.Base)
+		if err != nil {
+			return "", true, err
+		}
+		s := strconv.FormatInt(field.Int(), base)
+		if opts.Pad > 0 {
+			s = zeroPad(s, opts.Pad)
+		}
+		return s, true, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if opts.Base == 0 && opts.Pad == 0 {
+			return "", false, nil
+		}
+		base, err := normalizeBase(opts.Base)
+		if err != nil {
+			return "", true, err
+		}
+		s := strconv.FormatUint(field.Uint(), base)
+		if opts.Pad > 0 {
+			s = zeroPad(s, opts.Pad)
+		}
+		return s, true, nil
+	case reflect.Float32, reflect.Float64:
+		if opts.Prec == 0 && opts.FloatFmt == 0 {
+			return "", false, nil
+		}
+		bitSize := 64
+		if field.Kind() == reflect.Float32 {
+			bitSize = 32
+		}
+		prec := opts.Prec
+		if prec == 0 {
+			prec = -1
+		}
+		verb := opts.FloatFmt
+		if verb == 0 {
+			verb = 'f'
+		}
+		return strconv.FormatFloat(field.Float(), verb, prec, bitSize), true, nil
+	case reflect.Bool:
+		if opts.True == "" && opts.False == "" {
+			return "", false, nil
+		}
+		if field.Bool() {
+			if opts.True != "" {
+				return opts.True, true, nil
+			}
+			return "true", true, nil
+		}
+		if opts.False != "" {
+			return opts.False, true, nil
+		}
+		return "false", true, nil
+	}
+	return "", false, nil
+}
+
+// normalizeBase defaults base to 10 and validates it against the range
+// strconv.FormatInt/FormatUint accept, since unlike strconv.ParseInt/
+// ParseUint they panic on an out-of-range base instead of returning an error.
+func normalizeBase(base int) (int, error) {
+	if base == 0 {
+		return 10, nil
+	}
+	if base < 2 || base > 36 {
+		return 0, fmt.Errorf("gocsv: invalid base=%d tag, must be between 2 and 36", base)
+	}
+	return base, nil
+}
+
+func zeroPad(s string, width int) string {
+	neg := strings.HasPrefix(s, "-")
+	if neg {
+		s = s[1:]
+	}
+	for len(s) < width {
+		s = "0" + s
+	}
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
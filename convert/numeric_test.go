@@ -0,0 +1,73 @@
+package convert
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNumericFormatting(t *testing.T) {
+	type row struct {
+		Hex   int
+		Pad   int
+		Price float64
+		Flag  bool
+	}
+	r := row{Hex: 255, Pad: 7, Price: 19.999, Flag: true}
+	v := reflect.ValueOf(&r).Elem()
+
+	if s, err := DefaultRegistry.GetFieldAsString(v.FieldByName("Hex"), FieldOptions{Base: 16}); err != nil || s != "ff" {
+		t.Fatalf("Hex = (%q, %v), want ff", s, err)
+	}
+
+	if s, err := DefaultRegistry.GetFieldAsString(v.FieldByName("Pad"), FieldOptions{Pad: 4}); err != nil || s != "0007" {
+		t.Fatalf("Pad = (%q, %v), want 0007", s, err)
+	}
+
+	if s, err := DefaultRegistry.GetFieldAsString(v.FieldByName("Price"), FieldOptions{Prec: 2}); err != nil || s != "20.00" {
+		t.Fatalf("Price = (%q, %v), want 20.00", s, err)
+	}
+
+	if s, err := DefaultRegistry.GetFieldAsString(v.FieldByName("Flag"), FieldOptions{True: "Y", False: "N"}); err != nil || s != "Y" {
+		t.Fatalf("Flag = (%q, %v), want Y", s, err)
+	}
+}
+
+func TestSetNumericFieldBaseAndBoolTokens(t *testing.T) {
+	type row struct {
+		Hex  int
+		Flag bool
+	}
+	var r row
+	v := reflect.ValueOf(&r).Elem()
+
+	if err := DefaultRegistry.SetField(v.FieldByName("Hex"), "ff", 0, "Hex", FieldOptions{Base: 16}); err != nil {
+		t.Fatalf("SetField(Hex): %v", err)
+	}
+	if r.Hex != 255 {
+		t.Fatalf("Hex = %d, want 255", r.Hex)
+	}
+
+	if err := DefaultRegistry.SetField(v.FieldByName("Flag"), "Y", 0, "Flag", FieldOptions{True: "Y", False: "N"}); err != nil {
+		t.Fatalf("SetField(Flag, Y): %v", err)
+	}
+	if !r.Flag {
+		t.Fatalf("Flag = %v, want true", r.Flag)
+	}
+	// Built-in tokens still work alongside the custom ones (superset, not replacement).
+	if err := DefaultRegistry.SetField(v.FieldByName("Flag"), "false", 0, "Flag", FieldOptions{True: "Y", False: "N"}); err != nil {
+		t.Fatalf("SetField(Flag, false): %v", err)
+	}
+	if r.Flag {
+		t.Fatalf("Flag = %v, want false", r.Flag)
+	}
+}
+
+func TestGetNumericAsStringInvalidBase(t *testing.T) {
+	type row struct{ X int }
+	r := row{X: 5}
+	v := reflect.ValueOf(&r).Elem()
+
+	if _, _, err := DefaultRegistry.getNumericAsString(v.FieldByName("X"), FieldOptions{Base: 1}); err == nil {
+		t.Fatal("getNumericAsString with base=1 should return an error, not panic")
+	}
+}
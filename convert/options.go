@@ -0,0 +1,121 @@
+package convert
+
+import (
+	"strconv"
+	"strings"
+)
+
+// FieldOptions captures the extended, per-field directives that can follow a
+// field's name in a csv struct tag, e.g.
+// `csv:"created_at,format=2006-01-02 15:04:05,tz=UTC"`. The zero value means
+// "no extended directives" and conversion falls back to the type's default
+// representation.
+type FieldOptions struct {
+	// Format controls time.Time conversion. It is either a reference-time
+	// layout (as accepted by time.Parse/Time.Format), or one of the special
+	// values "unix", "unix_ms", "rfc3339" (the default when empty).
+	Format string
+	// TZ names the time.Location (via time.LoadLocation) for a time.Time.
+	// When parsing, it is passed to time.ParseInLocation for "rfc3339" and
+	// custom layouts, and "unix"/"unix_ms" results are converted to it. When
+	// formatting, it applies to custom layouts only; "rfc3339", "unix" and
+	// "unix_ms" output does not use it. Ignored for time.Duration fields.
+	TZ string
+	// Sep separates the elements of a slice/array field, or the entries of
+	// a map field. Defaults to "," when empty.
+	Sep string
+	// KV separates a map entry's key from its value, e.g. "=" in "k1=v1".
+	// Defaults to "=" when empty. Unused outside of map fields.
+	KV string
+	// Hex, when set, encodes/decodes a []byte field as a hex string instead
+	// of the default base64.
+	Hex bool
+	// Base is the integer base used by the base= tag (e.g. 2, 8, 16) for
+	// int/uint fields. 0 means unset, i.e. base 10.
+	Base int
+	// Prec is the float precision used by the prec= tag, passed to
+	// strconv.FormatFloat. 0 means unset, i.e. gocsv picks the shortest
+	// representation that round-trips (so prec=0 itself can't be
+	// requested).
+	Prec int
+	// FloatFmt is the format verb used by the fmt= tag ('f', 'e', or 'g'),
+	// passed to strconv.FormatFloat. 0 means unset, i.e. 'f'.
+	FloatFmt byte
+	// Pad is the zero-padding width used by the pad= tag for int/uint
+	// fields. 0 means unset, i.e. no padding.
+	Pad int
+	// True and False are the tokens used by the true=/false= tags when
+	// marshalling a bool field. When unmarshalling, they are accepted in
+	// addition to (not instead of) the built-in true/yes/1/false/no/0
+	// tokens. Empty means unset, i.e. the built-in "true"/"false" tokens.
+	True  string
+	False string
+}
+
+func (o FieldOptions) sep() string {
+	if o.Sep == "" {
+		return ","
+	}
+	return o.Sep
+}
+
+func (o FieldOptions) kv() string {
+	if o.KV == "" {
+		return "="
+	}
+	return o.KV
+}
+
+// ParseFieldOptions parses the comma-separated key=value directives that
+// follow a field's name in a csv struct tag (for example the
+// "format=...,tz=..." portion of `csv:"created_at,format=...,tz=UTC"`).
+// Unrecognized keys are ignored so this can be extended without breaking
+// existing tags.
+func ParseFieldOptions(directives string) FieldOptions {
+	var opts FieldOptions
+	for _, part := range strings.Split(directives, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if part == "hex" {
+			opts.Hex = true
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "format":
+			opts.Format = value
+		case "tz":
+			opts.TZ = value
+		case "sep":
+			opts.Sep = value
+		case "kv":
+			opts.KV = value
+		case "base":
+			if n, err := strconv.Atoi(value); err == nil {
+				opts.Base = n
+			}
+		case "prec":
+			if n, err := strconv.Atoi(value); err == nil {
+				opts.Prec = n
+			}
+		case "fmt":
+			if len(value) == 1 {
+				opts.FloatFmt = value[0]
+			}
+		case "pad":
+			if n, err := strconv.Atoi(value); err == nil {
+				opts.Pad = n
+			}
+		case "true":
+			opts.True = value
+		case "false":
+			opts.False = value
+		}
+	}
+	return opts
+}
@@ -0,0 +1,68 @@
+package convert
+
+import (
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestRegisterTypeConverterRoundTrip(t *testing.T) {
+	reg := NewRegistry()
+	reg.RegisterTypeConverter(reflect.TypeOf(net.IP{}),
+		func(v reflect.Value) (string, error) {
+			return v.Interface().(net.IP).String(), nil
+		},
+		func(v reflect.Value, s string) error {
+			ip := net.ParseIP(s)
+			if ip == nil {
+				return errors.New("invalid IP")
+			}
+			v.Set(reflect.ValueOf(ip))
+			return nil
+		},
+	)
+
+	type row struct {
+		Addr net.IP
+	}
+	var r row
+	v := reflect.ValueOf(&r).Elem()
+
+	if err := reg.SetField(v.FieldByName("Addr"), "127.0.0.1", 0, "Addr", FieldOptions{}); err != nil {
+		t.Fatalf("SetField: %v", err)
+	}
+	if r.Addr.String() != "127.0.0.1" {
+		t.Fatalf("Addr = %v, want 127.0.0.1", r.Addr)
+	}
+
+	s, err := reg.GetFieldAsString(v.FieldByName("Addr"), FieldOptions{})
+	if err != nil {
+		t.Fatalf("GetFieldAsString: %v", err)
+	}
+	if s != "127.0.0.1" {
+		t.Fatalf("GetFieldAsString = %q, want 127.0.0.1", s)
+	}
+}
+
+func TestRegistriesAreIsolated(t *testing.T) {
+	reg := NewRegistry()
+	reg.RegisterTypeConverter(reflect.TypeOf(0),
+		func(v reflect.Value) (string, error) { return "custom", nil },
+		nil,
+	)
+
+	type row struct{ X int }
+	r := row{X: 5}
+	v := reflect.ValueOf(&r).Elem()
+
+	s, err := reg.GetFieldAsString(v.FieldByName("X"), FieldOptions{})
+	if err != nil || s != "custom" {
+		t.Fatalf("reg.GetFieldAsString = (%q, %v), want custom", s, err)
+	}
+
+	s, err = DefaultRegistry.GetFieldAsString(v.FieldByName("X"), FieldOptions{})
+	if err != nil || s != "5" {
+		t.Fatalf("DefaultRegistry.GetFieldAsString = (%q, %v), want 5 (unaffected by reg)", s, err)
+	}
+}
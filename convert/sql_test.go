@@ -0,0 +1,102 @@
+package convert
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type fakeScanner struct {
+	got string
+}
+
+func (f *fakeScanner) Scan(src interface{}) error {
+	f.got = src.(string)
+	return nil
+}
+
+type fakeValuer struct {
+	v string
+}
+
+func (f fakeValuer) Value() (driver.Value, error) {
+	return f.v, nil
+}
+
+func TestUnmarshallScannerFallback(t *testing.T) {
+	type row struct {
+		S fakeScanner
+	}
+	var r row
+	v := reflect.ValueOf(&r).Elem()
+
+	if err := DefaultRegistry.unmarshall(v.FieldByName("S"), "hello"); err != nil {
+		t.Fatalf("unmarshall: %v", err)
+	}
+	if r.S.got != "hello" {
+		t.Fatalf("S.got = %q, want hello", r.S.got)
+	}
+}
+
+func TestMarshallValuerFallback(t *testing.T) {
+	type row struct {
+		V fakeValuer
+	}
+	r := row{V: fakeValuer{v: "world"}}
+	v := reflect.ValueOf(&r).Elem()
+
+	s, err := DefaultRegistry.marshall(v.FieldByName("V"))
+	if err != nil {
+		t.Fatalf("marshall: %v", err)
+	}
+	if s != "world" {
+		t.Fatalf("marshall = %q, want world", s)
+	}
+}
+
+// TestMarshallValuerTimeFallback guards against driver.Value's time.Time
+// case being piped through toString, which doesn't handle it: sql.NullTime
+// is the request's own motivating example.
+func TestMarshallValuerTimeFallback(t *testing.T) {
+	type row struct {
+		At sql.NullTime
+	}
+	at := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	r := row{At: sql.NullTime{Time: at, Valid: true}}
+	v := reflect.ValueOf(&r).Elem()
+
+	s, err := DefaultRegistry.marshall(v.FieldByName("At"))
+	if err != nil {
+		t.Fatalf("marshall: %v", err)
+	}
+	want, _ := formatTime(at, FieldOptions{})
+	if s != want {
+		t.Fatalf("marshall = %q, want %q", s, want)
+	}
+}
+
+// TestMarshallValuerBytesFallback guards against driver.Value's []byte case
+// being piped through toString, which doesn't handle it either.
+func TestMarshallValuerBytesFallback(t *testing.T) {
+	type row struct {
+		V fakeBytesValuer
+	}
+	r := row{V: fakeBytesValuer{b: []byte("hi")}}
+	v := reflect.ValueOf(&r).Elem()
+
+	s, err := DefaultRegistry.marshall(v.FieldByName("V"))
+	if err != nil {
+		t.Fatalf("marshall: %v", err)
+	}
+	if s != "aGk=" {
+		t.Fatalf("marshall = %q, want aGk=", s)
+	}
+}
+
+type fakeBytesValuer struct{ b []byte }
+
+func (f fakeBytesValuer) Value() (driver.Value, error) {
+	return f.b, nil
+}
@@ -0,0 +1,143 @@
+package convert
+
+import (
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// setTimeField handles time.Time, *time.Time, time.Duration, and
+// *time.Duration fields, which need layout/timezone-aware parsing instead of
+// gocsv's generic kind-based conversion. The bool result reports whether
+// field was one of these types; when false, the caller should fall through
+// to the rest of its conversion pipeline.
+func setTimeField(field reflect.Value, value string, opts FieldOptions) (bool, error) {
+	t := field.Type()
+	ptr := t.Kind() == reflect.Ptr
+	elem := t
+	if ptr {
+		elem = t.Elem()
+	}
+
+	switch elem {
+	case timeType:
+		if value == "" {
+			field.Set(reflect.Zero(t))
+			return true, nil
+		}
+		parsed, err := parseTime(value, opts)
+		if err != nil {
+			return true, err
+		}
+		if ptr {
+			field.Set(reflect.ValueOf(&parsed))
+		} else {
+			field.Set(reflect.ValueOf(parsed))
+		}
+		return true, nil
+	case durationType:
+		if value == "" {
+			field.Set(reflect.Zero(t))
+			return true, nil
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return true, err
+		}
+		if ptr {
+			field.Set(reflect.ValueOf(&d))
+		} else {
+			field.SetInt(int64(d))
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// getTimeField is the encode-side counterpart of setTimeField.
+func getTimeField(field reflect.Value, opts FieldOptions) (string, bool, error) {
+	t := field.Type()
+	ptr := t.Kind() == reflect.Ptr
+	elem := t
+	if ptr {
+		elem = t.Elem()
+	}
+
+	switch elem {
+	case timeType:
+		if ptr && field.IsNil() {
+			return "", true, nil
+		}
+		tv := field
+		if ptr {
+			tv = field.Elem()
+		}
+		s, err := formatTime(tv.Interface().(time.Time), opts)
+		return s, true, err
+	case durationType:
+		if ptr && field.IsNil() {
+			return "", true, nil
+		}
+		dv := field
+		if ptr {
+			dv = field.Elem()
+		}
+		return time.Duration(dv.Int()).String(), true, nil
+	}
+	return "", false, nil
+}
+
+func parseTime(value string, opts FieldOptions) (time.Time, error) {
+	loc := time.UTC
+	if opts.TZ != "" {
+		l, err := time.LoadLocation(opts.TZ)
+		if err != nil {
+			return time.Time{}, err
+		}
+		loc = l
+	}
+
+	switch opts.Format {
+	case "", "rfc3339":
+		return time.ParseInLocation(time.RFC3339, value, loc)
+	case "unix":
+		sec, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Unix(sec, 0).In(loc), nil
+	case "unix_ms":
+		ms, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.UnixMilli(ms).In(loc), nil
+	default:
+		return time.ParseInLocation(opts.Format, value, loc)
+	}
+}
+
+func formatTime(t time.Time, opts FieldOptions) (string, error) {
+	switch opts.Format {
+	case "", "rfc3339":
+		return t.Format(time.RFC3339Nano), nil
+	case "unix":
+		return strconv.FormatInt(t.Unix(), 10), nil
+	case "unix_ms":
+		return strconv.FormatInt(t.UnixMilli(), 10), nil
+	default:
+		if opts.TZ != "" {
+			loc, err := time.LoadLocation(opts.TZ)
+			if err != nil {
+				return "", err
+			}
+			t = t.In(loc)
+		}
+		return t.Format(opts.Format), nil
+	}
+}
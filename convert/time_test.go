@@ -0,0 +1,99 @@
+package convert
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSetTimeFieldDurationPointer(t *testing.T) {
+	type row struct {
+		D    time.Duration
+		DPtr *time.Duration
+	}
+	var r row
+	v := reflect.ValueOf(&r).Elem()
+
+	handled, err := setTimeField(v.FieldByName("D"), "1h30m", FieldOptions{})
+	if !handled || err != nil {
+		t.Fatalf("setTimeField(D) = (%v, %v)", handled, err)
+	}
+	if r.D != 90*time.Minute {
+		t.Fatalf("D = %v, want 90m", r.D)
+	}
+
+	handled, err = setTimeField(v.FieldByName("DPtr"), "45s", FieldOptions{})
+	if !handled || err != nil {
+		t.Fatalf("setTimeField(DPtr) = (%v, %v)", handled, err)
+	}
+	if r.DPtr == nil || *r.DPtr != 45*time.Second {
+		t.Fatalf("DPtr = %v, want 45s", r.DPtr)
+	}
+
+	s, handled, err := getTimeField(v.FieldByName("DPtr"), FieldOptions{})
+	if !handled || err != nil {
+		t.Fatalf("getTimeField(DPtr) = (%q, %v, %v)", s, handled, err)
+	}
+	if s != "45s" {
+		t.Fatalf("getTimeField(DPtr) = %q, want 45s", s)
+	}
+
+	handled, err = setTimeField(v.FieldByName("DPtr"), "", FieldOptions{})
+	if !handled || err != nil {
+		t.Fatalf("setTimeField(DPtr, \"\") = (%v, %v)", handled, err)
+	}
+	if r.DPtr != nil {
+		t.Fatalf("DPtr = %v, want nil after empty string", r.DPtr)
+	}
+}
+
+func TestSetTimeFieldTimePointer(t *testing.T) {
+	type row struct {
+		At *time.Time
+	}
+	var r row
+	v := reflect.ValueOf(&r).Elem()
+	opts := FieldOptions{Format: "2006-01-02", TZ: "UTC"}
+
+	handled, err := setTimeField(v.FieldByName("At"), "2020-03-04", opts)
+	if !handled || err != nil {
+		t.Fatalf("setTimeField(At) = (%v, %v)", handled, err)
+	}
+	if r.At == nil || r.At.Format("2006-01-02") != "2020-03-04" {
+		t.Fatalf("At = %v, want 2020-03-04", r.At)
+	}
+
+	s, handled, err := getTimeField(v.FieldByName("At"), opts)
+	if !handled || err != nil {
+		t.Fatalf("getTimeField(At) = (%q, %v, %v)", s, handled, err)
+	}
+	if s != "2020-03-04" {
+		t.Fatalf("getTimeField(At) = %q, want 2020-03-04", s)
+	}
+}
+
+// TestTimeFieldPreservesFractionalSeconds guards against the default RFC3339
+// format round-trip silently truncating sub-second precision: parseTime
+// accepts a fractional second even though time.RFC3339 doesn't declare one,
+// but formatTime must use a layout that can emit it back out too.
+func TestTimeFieldPreservesFractionalSeconds(t *testing.T) {
+	type row struct {
+		At time.Time
+	}
+	var r row
+	v := reflect.ValueOf(&r).Elem()
+
+	const in = "2024-01-15T10:30:00.123456789Z"
+	handled, err := setTimeField(v.FieldByName("At"), in, FieldOptions{})
+	if !handled || err != nil {
+		t.Fatalf("setTimeField(At) = (%v, %v)", handled, err)
+	}
+
+	s, handled, err := getTimeField(v.FieldByName("At"), FieldOptions{})
+	if !handled || err != nil {
+		t.Fatalf("getTimeField(At) = (%q, %v, %v)", s, handled, err)
+	}
+	if s != in {
+		t.Fatalf("getTimeField(At) = %q, want %q (fractional seconds must round-trip)", s, in)
+	}
+}